@@ -0,0 +1,763 @@
+// Package cqlr maps CQL query results onto Go structs (and back again)
+// using reflection, so that callers of gocql do not have to hand write
+// positional Scan/Query argument lists for every statement.
+package cqlr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// ErrMissingStrategy is returned by Binding.Close when Strict has been
+// requested and a column could not be resolved to a struct field by any
+// of the configured strategies (tag, Map, Use, or reflection).
+var ErrMissingStrategy = errors.New("cqlr: no strategy could bind a field for one or more columns")
+
+// A NameStrategy converts a struct field name into the column name it is
+// expected to bind to, so that a field like InsertionTime can be matched
+// against a column like insertion_time without a tag or a Map entry.
+type NameStrategy interface {
+	Convert(fieldName string) string
+}
+
+// NameStrategyFunc adapts a plain func(string) string into a
+// NameStrategy.
+type NameStrategyFunc func(string) string
+
+// Convert implements NameStrategy.
+func (f NameStrategyFunc) Convert(fieldName string) string {
+	return f(fieldName)
+}
+
+// SnakeCase converts a field name like InsertionTime into insertion_time.
+var SnakeCase NameStrategy = NameStrategyFunc(snakeCase)
+
+// CamelCase converts a field name like InsertionTime into
+// insertionTime.
+var CamelCase NameStrategy = NameStrategyFunc(camelCase)
+
+// LowerCase converts a field name like InsertionTime into
+// insertiontime.
+var LowerCase NameStrategy = NameStrategyFunc(strings.ToLower)
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+func camelCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// exportedFieldCache memoizes the exported fields of a struct type, in
+// declaration order, so that repeated Bind/Upsert calls for the same
+// struct type (e.g. inserting thousands of rows in a loop) don't re-walk
+// the type's fields with reflection every time.
+var exportedFieldCache sync.Map // reflect.Type -> []reflect.StructField
+
+// exportedFields returns the exported fields of t, in declaration order.
+func exportedFields(t reflect.Type) []reflect.StructField {
+	if v, ok := exportedFieldCache.Load(t); ok {
+		return v.([]reflect.StructField)
+	}
+
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.PkgPath == "" {
+			fields = append(fields, f)
+		}
+	}
+
+	exportedFieldCache.Store(t, fields)
+	return fields
+}
+
+// scanFieldCache memoizes the column-to-field resolution computed by
+// resolve, keyed by struct type and result column set, so that binding
+// the same query shape to the same struct type doesn't repeat the
+// tag/name matching on every call. It is only consulted when a Binding
+// carries no Map, Use or Names configuration, since those make
+// resolution depend on more than just the type and the columns; gocql
+// itself already caches prepared statements per session, so cqlr does
+// not duplicate that.
+var scanFieldCache sync.Map // scanCacheKey -> [][]int
+
+type scanCacheKey struct {
+	typ  reflect.Type
+	cols string
+}
+
+func columnSetKey(cols []gocql.ColumnInfo) string {
+	var b strings.Builder
+	for i, c := range cols {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+		b.WriteString(c.Keyspace)
+		b.WriteByte('.')
+		b.WriteString(c.Table)
+		b.WriteByte('.')
+		b.WriteString(c.Name)
+	}
+	return b.String()
+}
+
+// Reset clears cqlr's field-resolution cache. It is meant for test
+// isolation, where the same struct type may be reused across cases that
+// expect different resolution behavior. Reset clears the caches in
+// place, rather than replacing the underlying sync.Map, so that it is
+// itself safe to call while other goroutines are binding structs.
+func Reset() {
+	clearSyncMap(&exportedFieldCache)
+	clearSyncMap(&scanFieldCache)
+}
+
+func clearSyncMap(m *sync.Map) {
+	m.Range(func(k, _ interface{}) bool {
+		m.Delete(k)
+		return true
+	})
+}
+
+// Purge removes any cached field resolution for t.
+func Purge(t reflect.Type) {
+	exportedFieldCache.Delete(t)
+	scanFieldCache.Range(func(k, v interface{}) bool {
+		if key, ok := k.(scanCacheKey); ok && key.typ == t {
+			scanFieldCache.Delete(k)
+		}
+		return true
+	})
+}
+
+// Binding maps values from a struct onto CQL statement arguments and CQL
+// query results onto struct fields via reflection.
+type Binding struct {
+	stmt string
+	args []interface{}
+
+	q   *gocql.Query
+	itr *gocql.Iter
+
+	table     string
+	upsertVal interface{}
+	skipZero  bool
+
+	pageSize int
+
+	mapping         map[string]string
+	strategy        func(gocql.ColumnInfo) (reflect.StructField, bool)
+	reverseStrategy func(reflect.StructField) (string, bool)
+	names           NameStrategy
+	strict          bool
+
+	cols    []gocql.ColumnInfo
+	indexes [][]int
+	err     error
+}
+
+// Bind derives the arguments for stmt from the exported fields of v, in
+// declaration order, so that a caller can write
+//
+//	cqlr.Bind(`INSERT INTO tweet (timeline, id, text) VALUES (?, ?, ?)`, tw).Exec(s)
+//
+// instead of listing tw's fields by hand.
+func Bind(stmt string, v interface{}) *Binding {
+	return &Binding{
+		stmt: stmt,
+		args: structArgs(v),
+	}
+}
+
+// structArgs returns the values of the exported fields of v, in
+// declaration order, suitable for use as CQL statement arguments.
+func structArgs(v interface{}) []interface{} {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	fields := exportedFields(val.Type())
+
+	args := make([]interface{}, len(fields))
+	for i, f := range fields {
+		args[i] = val.FieldByIndex(f.Index).Interface()
+	}
+
+	return args
+}
+
+// BindQuery wraps q so that its results can be scanned directly onto
+// struct values with Scan.
+func BindQuery(q *gocql.Query) *Binding {
+	return &Binding{q: q}
+}
+
+// Upsert derives an INSERT statement for table from the exported fields
+// of v, so that a caller can write
+//
+//	cqlr.Upsert("tweet", tw).Exec(s)
+//
+// instead of hand writing the column list and positional placeholders.
+// The column for each field is resolved in reverse of how Scan resolves
+// a field for a column: a `cql:"..."` tag wins, then a Map override,
+// then UseReverse, then Names, then the field name lower-cased.
+func Upsert(table string, v interface{}) *Binding {
+	return &Binding{table: table, upsertVal: v}
+}
+
+// Map overrides the default, case-insensitive column-to-field matching
+// with an explicit column name to field name mapping. Upsert consults
+// the same mapping in reverse, to go from field name to column name.
+func (b *Binding) Map(m map[string]string) *Binding {
+	b.mapping = m
+	return b
+}
+
+// Use supplies a strategy function that resolves a column to a struct
+// field. It is consulted after tags and Map, and before the default
+// reflection-based fallback. A column-to-field function generally can't
+// be inverted automatically, so Upsert does not consult it in reverse;
+// pair Use with UseReverse if the same Binding is also used for Upsert.
+func (b *Binding) Use(strategy func(gocql.ColumnInfo) (reflect.StructField, bool)) *Binding {
+	b.strategy = strategy
+	return b
+}
+
+// UseReverse supplies the field-to-column-name counterpart to Use, so
+// that Upsert can resolve a column name for a field without guessing at
+// an inverse of the forward strategy. It is consulted after tags and
+// Map, and before Names and the default lower-casing fallback.
+func (b *Binding) UseReverse(strategy func(reflect.StructField) (string, bool)) *Binding {
+	b.reverseStrategy = strategy
+	return b
+}
+
+// Names attaches a NameStrategy that is consulted, for Scan, after tags,
+// Map and Use have all failed to resolve a field, and before the final
+// case-insensitive fallback. For Upsert it is consulted after tags, Map
+// and UseReverse, and its result is used in place of the lower-casing
+// fallback.
+func (b *Binding) Names(strategy NameStrategy) *Binding {
+	b.names = strategy
+	return b
+}
+
+// Strict causes Close to return ErrMissingStrategy if any column could
+// not be resolved to a struct field, instead of silently ignoring it.
+// This also covers a UDT's own fields: one that can't be resolved sets
+// the same error.
+func (b *Binding) Strict() *Binding {
+	b.strict = true
+	return b
+}
+
+// SkipZero causes Upsert to omit any nil pointer field from the
+// generated statement, so that a partially populated struct can be used
+// to perform a partial update rather than clobbering every column.
+func (b *Binding) SkipZero() *Binding {
+	b.skipZero = true
+	return b
+}
+
+// Paged sets the page size gocql uses to fetch the bound query's results,
+// so that a query over a large result set is fetched incrementally
+// rather than all at once.
+func (b *Binding) Paged(pageSize int) *Binding {
+	b.pageSize = pageSize
+	return b
+}
+
+// Exec executes the bound statement against s.
+func (b *Binding) Exec(s *gocql.Session) error {
+	b.composeUpsert()
+	return s.Query(b.stmt, b.args...).Exec()
+}
+
+// Batch appends the bound statement to batch, so that it can be executed
+// atomically alongside other bindings via session.ExecuteBatch.
+func (b *Binding) Batch(batch *gocql.Batch) *gocql.Batch {
+	b.composeUpsert()
+	batch.Query(b.stmt, b.args...)
+	return batch
+}
+
+// BindBatch derives the arguments for stmt from the exported fields of v,
+// in declaration order, and appends the resulting statement to batch. It
+// is the batch equivalent of Bind, letting callers accumulate many
+// struct-derived statements before executing them together, e.g.
+//
+//	batch := gocql.NewBatch(gocql.LoggedBatch)
+//	for _, tw := range tweets {
+//		cqlr.BindBatch(batch, `INSERT INTO tweet (timeline, id, text) VALUES (?, ?, ?)`, tw)
+//	}
+//	session.ExecuteBatch(batch)
+func BindBatch(batch *gocql.Batch, stmt string, v interface{}) *gocql.Batch {
+	batch.Query(stmt, structArgs(v)...)
+	return batch
+}
+
+// Scan reads the next row of the bound query into v, which must be a
+// pointer to a struct. It returns false when there are no more rows or
+// an error occurred, in which case the error is available from Close.
+func (b *Binding) Scan(v interface{}) bool {
+	if b.itr == nil {
+		if b.pageSize > 0 {
+			b.q = b.q.PageSize(b.pageSize)
+		}
+		b.itr = b.q.Iter()
+		b.cols = b.itr.Columns()
+		b.resolve(reflect.TypeOf(v).Elem())
+		if b.err != nil {
+			return false
+		}
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(v))
+	dest := make([]interface{}, len(b.cols))
+	var conversions []func()
+
+	for i, idx := range b.indexes {
+		if idx == nil {
+			var discard interface{}
+			dest[i] = &discard
+			continue
+		}
+
+		fv := val.FieldByIndex(idx)
+		switch {
+		case isUDT(fv.Type()):
+			dest[i] = b.wrapUDT(fv)
+		case fv.Kind() == reflect.Slice && isUDT(fv.Type().Elem()):
+			raw := new([]map[string]interface{})
+			dest[i] = raw
+			conversions = append(conversions, func() { b.scanUDTSlice(fv, *raw) })
+		case fv.Kind() == reflect.Map && isUDT(fv.Type().Elem()):
+			rawPtr := reflect.New(reflect.MapOf(fv.Type().Key(), reflect.TypeOf(map[string]interface{}(nil))))
+			dest[i] = rawPtr.Interface()
+			conversions = append(conversions, func() { b.scanUDTMap(fv, rawPtr.Elem()) })
+		default:
+			dest[i] = fv.Addr().Interface()
+		}
+	}
+
+	if !b.itr.Scan(dest...) {
+		return false
+	}
+
+	for _, convert := range conversions {
+		convert()
+	}
+	return true
+}
+
+// scanUDTSlice populates dst, a []T field where T is a UDT struct, from
+// raw, the generic per-element data gocql decoded a list<frozen<T>>
+// column into.
+func (b *Binding) scanUDTSlice(dst reflect.Value, raw []map[string]interface{}) {
+	elems := reflect.MakeSlice(dst.Type(), len(raw), len(raw))
+	for i, m := range raw {
+		b.fromUDTMap(elems.Index(i), m)
+	}
+	dst.Set(elems)
+}
+
+// scanUDTMap populates dst, a map[K]T field where T is a UDT struct,
+// from raw, the generic per-value data gocql decoded a
+// map<K, frozen<T>> column into.
+func (b *Binding) scanUDTMap(dst reflect.Value, raw reflect.Value) {
+	result := reflect.MakeMapWithSize(dst.Type(), raw.Len())
+	iter := raw.MapRange()
+	for iter.Next() {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		b.fromUDTMap(elem, iter.Value().Interface().(map[string]interface{}))
+		result.SetMapIndex(iter.Key(), elem)
+	}
+	dst.Set(result)
+}
+
+// fromUDTMap copies the fields of raw, a UDT decoded generically by
+// gocql into a map of column name to value, onto dst, resolving each
+// name the same way a top-level UDT field does.
+func (b *Binding) fromUDTMap(dst reflect.Value, raw map[string]interface{}) {
+	t := dst.Type()
+	for name, v := range raw {
+		if v == nil {
+			continue
+		}
+		f, ok := resolveUDTField(t, name, b.mapping, b.strategy, b.names)
+		if !ok {
+			if b.strict {
+				b.err = ErrMissingStrategy
+			}
+			continue
+		}
+		target := dst.FieldByIndex(f.Index)
+		rv := reflect.ValueOf(v)
+		if rv.Type().ConvertibleTo(target.Type()) {
+			target.Set(rv.Convert(target.Type()))
+		}
+	}
+}
+
+// Each drives Scan on b's behalf, passing each row to fn, which must be
+// a func(*T) (bool, error) for some struct type T. It stops when fn
+// returns false or an error, or when there are no more rows, and returns
+// any error from fn or from Close, so that callers do not have to write
+//
+//	for b.Scan(&v) { ... }
+//	err := b.Close()
+//
+// by hand.
+func (b *Binding) Each(fn interface{}) error {
+	fnVal := reflect.ValueOf(fn)
+	argType := fnVal.Type().In(0).Elem()
+
+	for {
+		v := reflect.New(argType)
+		if !b.Scan(v.Interface()) {
+			break
+		}
+
+		out := fnVal.Call([]reflect.Value{v})
+		if err, _ := out[1].Interface().(error); err != nil {
+			b.Close()
+			return err
+		}
+		if !out[0].Bool() {
+			break
+		}
+	}
+
+	return b.Close()
+}
+
+// Close releases the resources associated with the bound query and
+// returns the first error encountered, including ErrMissingStrategy when
+// Strict was requested.
+func (b *Binding) Close() error {
+	if b.itr == nil {
+		return b.err
+	}
+
+	cerr := b.itr.Close()
+	if b.err != nil {
+		return b.err
+	}
+	return cerr
+}
+
+// resolve computes, for every column in b.cols, the index path of the
+// struct field of t that it should be scanned into, honoring tags, Map,
+// Use, Names and finally case-insensitive field name matching, in that
+// order. The result is served from scanFieldCache when nothing but the
+// type and columns can affect it.
+func (b *Binding) resolve(t reflect.Type) {
+	cacheable := b.mapping == nil && b.strategy == nil && b.names == nil
+
+	var key scanCacheKey
+	if cacheable {
+		key = scanCacheKey{typ: t, cols: columnSetKey(b.cols)}
+		if v, ok := scanFieldCache.Load(key); ok {
+			b.indexes = v.([][]int)
+			if b.strict {
+				for _, idx := range b.indexes {
+					if idx == nil {
+						b.err = ErrMissingStrategy
+						break
+					}
+				}
+			}
+			return
+		}
+	}
+
+	b.indexes = make([][]int, len(b.cols))
+
+	for i, c := range b.cols {
+		if f, ok := fieldByTag(t, c.Name); ok {
+			b.indexes[i] = f.Index
+			continue
+		}
+
+		if b.mapping != nil {
+			if name, ok := b.mapping[c.Name]; ok {
+				if f, ok := t.FieldByName(name); ok {
+					b.indexes[i] = f.Index
+					continue
+				}
+			}
+		}
+
+		if b.strategy != nil {
+			if f, ok := b.strategy(c); ok {
+				b.indexes[i] = f.Index
+				continue
+			}
+		}
+
+		if b.names != nil {
+			if f, ok := fieldByStrategy(t, c.Name, b.names); ok {
+				b.indexes[i] = f.Index
+				continue
+			}
+		}
+
+		if f, ok := fieldByName(t, c.Name); ok {
+			b.indexes[i] = f.Index
+			continue
+		}
+
+		if b.strict {
+			b.err = ErrMissingStrategy
+		}
+	}
+
+	if cacheable {
+		scanFieldCache.Store(key, b.indexes)
+	}
+}
+
+// composeUpsert builds the INSERT statement and argument list for an
+// Upsert-derived Binding, if one hasn't already been built.
+func (b *Binding) composeUpsert() {
+	if b.table == "" || b.stmt != "" {
+		return
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(b.upsertVal))
+	fields := exportedFields(val.Type())
+
+	cols := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields))
+
+	for _, f := range fields {
+		fv := val.FieldByIndex(f.Index)
+		if b.skipZero && fv.Kind() == reflect.Ptr && fv.IsNil() {
+			continue
+		}
+
+		cols = append(cols, b.columnName(f))
+
+		switch {
+		case isUDT(fv.Type()):
+			args = append(args, b.wrapUDT(fv))
+		case fv.Kind() == reflect.Slice && isUDT(fv.Type().Elem()):
+			args = append(args, b.udtSliceMap(fv))
+		case fv.Kind() == reflect.Map && isUDT(fv.Type().Elem()):
+			args = append(args, b.udtMapMap(fv))
+		default:
+			args = append(args, fv.Interface())
+		}
+	}
+
+	placeholders := strings.Repeat("?, ", len(cols))
+	placeholders = strings.TrimSuffix(placeholders, ", ")
+
+	b.stmt = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", b.table, strings.Join(cols, ", "), placeholders)
+	b.args = args
+}
+
+// columnName resolves the CQL column that f should be written to: a
+// `cql:"..."` tag wins, then Map (inverted), then UseReverse, then
+// Names, then the field name lower-cased.
+func (b *Binding) columnName(f reflect.StructField) string {
+	if name := f.Tag.Get("cql"); name != "" {
+		return name
+	}
+
+	if b.mapping != nil {
+		for col, field := range b.mapping {
+			if field == f.Name {
+				return col
+			}
+		}
+	}
+
+	if b.reverseStrategy != nil {
+		if name, ok := b.reverseStrategy(f); ok {
+			return name
+		}
+	}
+
+	if b.names != nil {
+		return b.names.Convert(f.Name)
+	}
+
+	return strings.ToLower(f.Name)
+}
+
+// udtMap builds the generic column-name-to-value representation of a UDT
+// struct value v, for marshaling into a list<frozen<T>> or
+// map<K, frozen<T>> column.
+func (b *Binding) udtMap(v reflect.Value) map[string]interface{} {
+	fields := exportedFields(v.Type())
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[b.columnName(f)] = v.FieldByIndex(f.Index).Interface()
+	}
+	return m
+}
+
+// udtSliceMap converts a []T field, where T is a UDT struct, into the
+// []map[string]interface{} form gocql marshals a list<frozen<T>> column
+// from.
+func (b *Binding) udtSliceMap(fv reflect.Value) []map[string]interface{} {
+	raw := make([]map[string]interface{}, fv.Len())
+	for i := 0; i < fv.Len(); i++ {
+		raw[i] = b.udtMap(fv.Index(i))
+	}
+	return raw
+}
+
+// udtMapMap converts a map[K]T field, where T is a UDT struct, into the
+// map[K]map[string]interface{} form gocql marshals a
+// map<K, frozen<T>> column from.
+func (b *Binding) udtMapMap(fv reflect.Value) interface{} {
+	raw := reflect.MakeMapWithSize(reflect.MapOf(fv.Type().Key(), reflect.TypeOf(map[string]interface{}(nil))), fv.Len())
+	iter := fv.MapRange()
+	for iter.Next() {
+		raw.SetMapIndex(iter.Key(), reflect.ValueOf(b.udtMap(iter.Value())))
+	}
+	return raw.Interface()
+}
+
+// isUDT reports whether t should be bound to a Cassandra user-defined
+// type column by descending into its fields, rather than being handed to
+// gocql as-is. time.Time is a struct but is a native gocql type, not a
+// UDT; gocql.UUID is a [16]byte array, so the Kind check already
+// rejects it.
+func isUDT(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	switch t {
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(gocql.UUID{}):
+		return false
+	}
+	return true
+}
+
+// udtField adapts a struct field bound to a UDT column to gocql's
+// UDTUnmarshaler/UDTMarshaler interfaces, resolving each UDT field to a
+// struct field with the same tag/Map/Use/Names strategy Scan uses for
+// columns.
+type udtField struct {
+	val      reflect.Value
+	mapping  map[string]string
+	strategy func(gocql.ColumnInfo) (reflect.StructField, bool)
+	names    NameStrategy
+	strict   bool
+	err      *error
+}
+
+// wrapUDT adapts val, a struct field bound to a UDT column, carrying
+// along b's Map/Use/Names configuration so that the UDT's own fields are
+// resolved the same way b resolves top-level columns. When b is Strict,
+// a UDT field that fails to resolve sets b.err just like an unresolved
+// top-level column does.
+func (b *Binding) wrapUDT(val reflect.Value) *udtField {
+	return &udtField{val: val, mapping: b.mapping, strategy: b.strategy, names: b.names, strict: b.strict, err: &b.err}
+}
+
+func (u *udtField) fieldFor(name string) (reflect.Value, bool) {
+	f, ok := resolveUDTField(u.val.Type(), name, u.mapping, u.strategy, u.names)
+	if !ok {
+		if u.strict {
+			*u.err = ErrMissingStrategy
+		}
+		return reflect.Value{}, false
+	}
+	return u.val.FieldByIndex(f.Index), true
+}
+
+// resolveUDTField resolves a UDT field name to a struct field of t,
+// honoring a tag first, then mapping (Map), then strategy (Use) and
+// names (Names), and finally case-insensitive field name matching.
+func resolveUDTField(t reflect.Type, name string, mapping map[string]string, strategy func(gocql.ColumnInfo) (reflect.StructField, bool), names NameStrategy) (reflect.StructField, bool) {
+	if f, ok := fieldByTag(t, name); ok {
+		return f, true
+	}
+	if mapping != nil {
+		if fieldName, ok := mapping[name]; ok {
+			if f, ok := t.FieldByName(fieldName); ok {
+				return f, true
+			}
+		}
+	}
+	if strategy != nil {
+		if f, ok := strategy(gocql.ColumnInfo{Name: name}); ok {
+			return f, true
+		}
+	}
+	if names != nil {
+		if f, ok := fieldByStrategy(t, name, names); ok {
+			return f, true
+		}
+	}
+	return fieldByName(t, name)
+}
+
+// UnmarshalUDT implements gocql.UDTUnmarshaler.
+func (u *udtField) UnmarshalUDT(name string, info gocql.TypeInfo, data []byte) error {
+	target, ok := u.fieldFor(name)
+	if !ok {
+		return nil // unknown UDT field, ignore like an unknown column
+	}
+	return gocql.Unmarshal(info, data, target.Addr().Interface())
+}
+
+// MarshalUDT implements gocql.UDTMarshaler.
+func (u *udtField) MarshalUDT(name string, info gocql.TypeInfo) ([]byte, error) {
+	target, ok := u.fieldFor(name)
+	if !ok {
+		return nil, nil
+	}
+	return gocql.Marshal(info, target.Interface())
+}
+
+// fieldByStrategy looks for a field of t whose name, converted by
+// strategy, matches name, ignoring case.
+func fieldByStrategy(t reflect.Type, name string, strategy NameStrategy) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.EqualFold(strategy.Convert(f.Name), name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldByTag looks for a field of t tagged `cql:"name"`.
+func fieldByTag(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("cql") == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldByName looks for a field of t whose name matches name, ignoring
+// case.
+func fieldByName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"speter.net/go/exp/math/dec/inf"
+	"strings"
 	"testing"
 	"time"
 )
@@ -332,6 +333,527 @@ func TestIgnoreUnknownColumns(t *testing.T) {
 	assert.Nil(t, err, "Could not close binding")
 }
 
+func TestBindBatch(t *testing.T) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	s := setup(t, "tweet")
+
+	tweets := 100
+
+	batch := gocql.NewBatch(gocql.LoggedBatch)
+
+	for i := 0; i < tweets; i++ {
+		tw := Tweet{
+			Timeline: "batched",
+			Id:       gocql.TimeUUID(),
+			Text:     fmt.Sprintf("hello world %d", i),
+		}
+
+		BindBatch(batch, `INSERT INTO tweet (timeline, id, text) VALUES (?, ?, ?)`, tw)
+	}
+
+	if err := s.ExecuteBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	q := s.Query(`SELECT text, id, timeline FROM tweet WHERE timeline = ?`, "batched")
+	b := BindQuery(q)
+
+	count := 0
+	var tw Tweet
+
+	for b.Scan(&tw) {
+		count++
+		assert.Equal(t, "batched", tw.Timeline)
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, tweets, count)
+}
+
+func TestBindMethodBatch(t *testing.T) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	s := setup(t, "tweet")
+
+	tweets := 5
+
+	batch := gocql.NewBatch(gocql.UnloggedBatch)
+
+	for i := 0; i < tweets; i++ {
+		tw := Tweet{
+			Timeline: "chained",
+			Id:       gocql.TimeUUID(),
+			Text:     fmt.Sprintf("hello world %d", i),
+		}
+
+		Bind(`INSERT INTO tweet (timeline, id, text) VALUES (?, ?, ?)`, tw).Batch(batch)
+	}
+
+	if err := s.ExecuteBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	q := s.Query(`SELECT text, id, timeline FROM tweet WHERE timeline = ?`, "chained")
+	b := BindQuery(q)
+
+	count := 0
+	var tw Tweet
+
+	for b.Scan(&tw) {
+		count++
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, tweets, count)
+}
+
+func TestUpsert(t *testing.T) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	s := setup(t, "tweet")
+
+	tw := Tweet{
+		Timeline: "upserted",
+		Id:       gocql.TimeUUID(),
+		Text:     "hello upsert",
+	}
+
+	if err := Upsert("tweet", tw).Exec(s); err != nil {
+		t.Fatal(err)
+	}
+
+	q := s.Query(`SELECT text, id, timeline FROM tweet WHERE timeline = ?`, "upserted")
+	b := BindQuery(q)
+
+	count := 0
+	var out Tweet
+
+	for b.Scan(&out) {
+		count++
+		assert.Equal(t, tw.Text, out.Text)
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, 1, count)
+}
+
+func TestUpsertWithTagsAndSkipZero(t *testing.T) {
+
+	type Reading struct {
+		What    int32     `cql:"id"`
+		When    time.Time `cql:"timestamp"`
+		HowMuch *float32  `cql:"temperature"`
+	}
+
+	s := setup(t, "sensors")
+
+	r := Reading{
+		What: 7,
+		When: time.Now(),
+	}
+
+	if err := Upsert("sensors", r).SkipZero().Exec(s); err != nil {
+		t.Fatal(err)
+	}
+
+	q := s.Query(`SELECT id, timestamp, temperature FROM sensors WHERE id = ? ALLOW FILTERING`, 7)
+	b := BindQuery(q)
+
+	count := 0
+	var out Reading
+
+	for b.Scan(&out) {
+		count++
+		assert.Nil(t, out.HowMuch)
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, 1, count)
+}
+
+func TestUpsertWithUseReverse(t *testing.T) {
+
+	type WaterLevel struct {
+		Country       string
+		Precipitation int32
+	}
+
+	s := setup(t, "levels")
+
+	wl := WaterLevel{Country: "Antarctica", Precipitation: 100}
+
+	u := Upsert("levels", wl).UseReverse(func(f reflect.StructField) (string, bool) {
+		if f.Name == "Precipitation" {
+			return "rain", true
+		}
+		return "", false
+	})
+
+	if err := u.Exec(s); err != nil {
+		t.Fatal(err)
+	}
+
+	q := s.Query(`SELECT country, rain FROM levels WHERE country = ? ALLOW FILTERING`, "Antarctica")
+	b := BindQuery(q).Use(func(c gocql.ColumnInfo) (reflect.StructField, bool) {
+		if c.Name == "rain" {
+			st := reflect.TypeOf((*WaterLevel)(nil)).Elem()
+			return st.FieldByName("Precipitation")
+		}
+		return reflect.StructField{}, false
+	})
+
+	count := 0
+	var out WaterLevel
+
+	for b.Scan(&out) {
+		count++
+		assert.Equal(t, int32(100), out.Precipitation)
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, 1, count)
+}
+
+func TestUDTBinding(t *testing.T) {
+
+	type Charge struct {
+		Amount   *inf.Dec
+		Currency string
+	}
+
+	type Invoice struct {
+		Id     gocql.UUID
+		Charge Charge
+	}
+
+	s := setup(t, "invoices")
+
+	amount := new(inf.Dec)
+	amount.SetString("19.99")
+
+	inv := Invoice{
+		Id:     gocql.TimeUUID(),
+		Charge: Charge{Amount: amount, Currency: "USD"},
+	}
+
+	if err := Upsert("invoices", inv).Exec(s); err != nil {
+		t.Fatal(err)
+	}
+
+	q := s.Query(`SELECT id, charge FROM invoices WHERE id = ?`, inv.Id)
+	b := BindQuery(q)
+
+	count := 0
+	var out Invoice
+
+	for b.Scan(&out) {
+		count++
+		assert.Equal(t, "USD", out.Charge.Currency)
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, 1, count)
+}
+
+func TestUDTCollectionBinding(t *testing.T) {
+
+	type Charge struct {
+		Amount   *inf.Dec
+		Currency string
+	}
+
+	type Invoice struct {
+		Id        gocql.UUID
+		Charges   []Charge          // list<frozen<charge>>
+		ChargesBy map[string]Charge // map<text, frozen<charge>>
+	}
+
+	s := setup(t, "invoices")
+
+	amount := new(inf.Dec)
+	amount.SetString("19.99")
+
+	charge := Charge{Amount: amount, Currency: "USD"}
+
+	inv := Invoice{
+		Id:        gocql.TimeUUID(),
+		Charges:   []Charge{charge},
+		ChargesBy: map[string]Charge{"first": charge},
+	}
+
+	if err := Upsert("invoices", inv).Map(map[string]string{
+		"charges_by": "ChargesBy",
+	}).Exec(s); err != nil {
+		t.Fatal(err)
+	}
+
+	q := s.Query(`SELECT id, charges, charges_by FROM invoices WHERE id = ?`, inv.Id)
+	b := BindQuery(q).Map(map[string]string{
+		"charges_by": "ChargesBy",
+	})
+
+	count := 0
+	var out Invoice
+
+	for b.Scan(&out) {
+		count++
+		if assert.Equal(t, 1, len(out.Charges)) {
+			assert.Equal(t, "USD", out.Charges[0].Currency)
+		}
+		if assert.Equal(t, 1, len(out.ChargesBy)) {
+			assert.Equal(t, "USD", out.ChargesBy["first"].Currency)
+		}
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, 1, count)
+}
+
+func TestEach(t *testing.T) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	s := setup(t, "tweet")
+
+	tweets := 20
+
+	for i := 0; i < tweets; i++ {
+		tw := Tweet{
+			Timeline: "me",
+			Id:       gocql.TimeUUID(),
+			Text:     fmt.Sprintf("hello world %d", i),
+		}
+
+		if err := Bind(`INSERT INTO tweet (timeline, id, text) VALUES (?, ?, ?)`, tw).Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := s.Query(`SELECT text, id, timeline FROM tweet WHERE timeline = ?`, "me")
+	b := BindQuery(q).Paged(5)
+
+	count := 0
+	err := b.Each(func(tw *Tweet) (bool, error) {
+		count++
+		assert.Equal(t, "me", tw.Timeline)
+		return true, nil
+	})
+
+	assert.Nil(t, err, "Could not iterate binding")
+	assert.Equal(t, tweets, count)
+}
+
+func TestEachStopsEarly(t *testing.T) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	s := setup(t, "tweet")
+
+	tweets := 10
+
+	for i := 0; i < tweets; i++ {
+		tw := Tweet{
+			Timeline: "me",
+			Id:       gocql.TimeUUID(),
+			Text:     fmt.Sprintf("hello world %d", i),
+		}
+
+		if err := Bind(`INSERT INTO tweet (timeline, id, text) VALUES (?, ?, ?)`, tw).Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := s.Query(`SELECT text, id, timeline FROM tweet WHERE timeline = ?`, "me")
+	b := BindQuery(q)
+
+	count := 0
+	err := b.Each(func(tw *Tweet) (bool, error) {
+		count++
+		return count < 3, nil
+	})
+
+	assert.Nil(t, err, "Could not iterate binding")
+	assert.Equal(t, 3, count)
+}
+
+func TestNameStrategies(t *testing.T) {
+	assert.Equal(t, "insertion_time", SnakeCase.Convert("InsertionTime"))
+	assert.Equal(t, "id", SnakeCase.Convert("Id"))
+	assert.Equal(t, "insertionTime", CamelCase.Convert("InsertionTime"))
+	assert.Equal(t, "insertiontime", LowerCase.Convert("InsertionTime"))
+
+	upper := NameStrategyFunc(strings.ToUpper)
+	assert.Equal(t, "INSERTIONTIME", upper.Convert("InsertionTime"))
+}
+
+func TestNamesWithoutTagsOrMap(t *testing.T) {
+
+	type Reading struct {
+		Id          int32
+		Timestamp   time.Time
+		Temperature float32
+	}
+
+	s := setup(t, "sensors")
+
+	measurements := 6
+
+	for i := 0; i < measurements; i++ {
+		if err := s.Query(`INSERT INTO sensors (id, timestamp, temperature) VALUES (?, ?, ?)`,
+			i, time.Now(), float32(1)/3).Exec(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := s.Query(`SELECT id, timestamp, temperature FROM sensors`)
+
+	b := BindQuery(q).Names(SnakeCase)
+
+	count := 0
+	var r Reading
+
+	for b.Scan(&r) {
+		count++
+	}
+
+	err := b.Close()
+	assert.Nil(t, err, "Could not close binding")
+	assert.Equal(t, measurements, count)
+}
+
+func TestFieldCacheConcurrentAccess(t *testing.T) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	Reset()
+
+	done := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		go func() {
+			structArgs(Tweet{Timeline: "me", Id: gocql.TimeUUID(), Text: "hi"})
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		<-done
+	}
+
+	Purge(reflect.TypeOf(Tweet{}))
+}
+
+func BenchmarkBind(b *testing.B) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	tw := Tweet{
+		Timeline: "me",
+		Id:       gocql.TimeUUID(),
+		Text:     "hello world",
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Bind(`INSERT INTO tweet (timeline, id, text) VALUES (?, ?, ?)`, tw)
+	}
+}
+
+// BenchmarkScanResolve measures the column-to-field resolution Scan
+// performs on a Binding's first row once scanFieldCache already holds
+// the result, which is what every BindQuery after the first sees when
+// the same struct type and columns are bound again and again. Compare
+// against BenchmarkScanResolveUncached for the cost the cache saves.
+func BenchmarkScanResolve(b *testing.B) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	cols := []gocql.ColumnInfo{
+		{Keyspace: "cqlr", Table: "tweet", Name: "timeline"},
+		{Keyspace: "cqlr", Table: "tweet", Name: "id"},
+		{Keyspace: "cqlr", Table: "tweet", Name: "text"},
+	}
+	t := reflect.TypeOf(Tweet{})
+
+	Reset()
+	(&Binding{cols: cols}).resolve(t) // prime scanFieldCache
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		(&Binding{cols: cols}).resolve(t)
+	}
+}
+
+// BenchmarkScanResolveUncached measures the same resolution as
+// BenchmarkScanResolve, but with scanFieldCache cleared before every
+// call, so it pays full field-by-field resolution cost each time. The
+// gap between this and BenchmarkScanResolve is the win scanFieldCache
+// gives each repeated binding of the same struct and columns.
+func BenchmarkScanResolveUncached(b *testing.B) {
+
+	type Tweet struct {
+		Timeline string
+		Id       gocql.UUID
+		Text     string
+	}
+
+	cols := []gocql.ColumnInfo{
+		{Keyspace: "cqlr", Table: "tweet", Name: "timeline"},
+		{Keyspace: "cqlr", Table: "tweet", Name: "id"},
+		{Keyspace: "cqlr", Table: "tweet", Name: "text"},
+	}
+	t := reflect.TypeOf(Tweet{})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		Reset()
+		b.StartTimer()
+		(&Binding{cols: cols}).resolve(t)
+	}
+}
+
 func setup(t *testing.T, table string) *gocql.Session {
 	cluster := gocql.NewCluster("127.0.0.1")
 	cluster.Keyspace = "cqlr"